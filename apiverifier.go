@@ -0,0 +1,62 @@
+package verifier
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// APIVerifier lets callers short-circuit the SMTP probe for providers that
+// reject or throttle RCPT TO checks from unrecognized IPs (Gmail, Yahoo,
+// Outlook, ...), verifying through a provider-specific channel instead.
+type APIVerifier interface {
+	// IsSupported reports whether this adapter can verify mailboxes hosted
+	// on mxHost.
+	IsSupported(mxHost string) bool
+	// Check verifies whether username exists on domain. Its return value
+	// carries the same deliverability semantics as Verify.
+	Check(ctx context.Context, domain, username string) (bool, error)
+}
+
+// Read-write mutex for thread-safe access to apiVerifiers
+var apiVerifiersMu sync.RWMutex
+
+// Globally registered provider adapters, consulted by Verify after MX
+// resolution and before the SMTP dial
+var apiVerifiers []APIVerifier
+
+// RegisterAPIVerifier adds a custom APIVerifier to the set consulted by every
+// call to Verify. Adapters are tried in registration order; the first whose
+// IsSupported matches the chosen MX host wins. For a one-off adapter, prefer
+// Config.APIVerifiers instead.
+func RegisterAPIVerifier(v APIVerifier) {
+	apiVerifiersMu.Lock()
+	apiVerifiers = append(apiVerifiers, v)
+	apiVerifiersMu.Unlock()
+}
+
+// apiVerifierFor returns the first adapter that supports mxHost, checking
+// extra (typically Config.APIVerifiers) before the global registry, or nil
+// if none match.
+func apiVerifierFor(mxHost string, extra []APIVerifier) APIVerifier {
+	mxHost = strings.ToLower(mxHost)
+	for _, v := range extra {
+		if v.IsSupported(mxHost) {
+			return v
+		}
+	}
+	apiVerifiersMu.RLock()
+	defer apiVerifiersMu.RUnlock()
+	for _, v := range apiVerifiers {
+		if v.IsSupported(mxHost) {
+			return v
+		}
+	}
+	return nil
+}
+
+// init registers the built-in provider adapters
+func init() {
+	RegisterAPIVerifier(&gmailAPIVerifier{})
+	RegisterAPIVerifier(&yahooAPIVerifier{})
+}
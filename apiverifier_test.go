@@ -0,0 +1,58 @@
+package verifier
+
+import (
+	"context"
+	"testing"
+)
+
+type stubAPIVerifier struct {
+	supports string
+}
+
+func (s stubAPIVerifier) IsSupported(mxHost string) bool {
+	return mxHost == s.supports
+}
+
+func (stubAPIVerifier) Check(ctx context.Context, domain, username string) (bool, error) {
+	return true, nil
+}
+
+func TestAPIVerifierForBuiltins(t *testing.T) {
+	tests := []struct {
+		name   string
+		mxHost string
+		want   bool
+	}{
+		{"gmail mx", "aspmx.l.google.com", true},
+		{"yahoo mx", "mta7.am0.yahoodns.net", true},
+		{"mixed case host", "ASPMX.L.GOOGLE.COM", true},
+		{"unrelated host", "mx.example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := apiVerifierFor(tt.mxHost, nil)
+			if (got != nil) != tt.want {
+				t.Fatalf("apiVerifierFor(%q, nil) = %v, want match: %v", tt.mxHost, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIVerifierForPrefersExtraOverGlobal(t *testing.T) {
+	extra := []APIVerifier{stubAPIVerifier{supports: "aspmx.l.google.com"}}
+
+	got := apiVerifierFor("aspmx.l.google.com", extra)
+	if got == nil {
+		t.Fatal("expected a matching verifier, got nil")
+	}
+	if _, ok := got.(stubAPIVerifier); !ok {
+		t.Fatalf("expected extra verifier to take precedence over the global registry, got %T", got)
+	}
+}
+
+func TestAPIVerifierForNoMatch(t *testing.T) {
+	if got := apiVerifierFor("mx.example.com", nil); got != nil {
+		t.Fatalf("expected no match, got %T", got)
+	}
+}
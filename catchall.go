@@ -0,0 +1,62 @@
+package verifier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// Length of the random local part used to probe for catch-all acceptance
+const catchAllLocalPartLength = 16
+
+// How long a catch-all verdict is cached per domain
+const catchAllCacheTTL = 1 * time.Hour
+
+// Alphabet used to build the random local part; kept free of characters
+// some MTAs reject in a local part
+const catchAllCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+type catchAllEntry struct {
+	catchAll bool
+	expires  time.Time
+}
+
+// Read-write mutex for thread-safe access to catchAllCache
+var catchAllMu sync.RWMutex
+
+// Per-domain catch-all verdicts, keyed by domain
+var catchAllCache = map[string]catchAllEntry{}
+
+// catchAllCached returns the cached catch-all verdict for domain and whether
+// it is still within its TTL
+func catchAllCached(domain string) (bool, bool) {
+	catchAllMu.RLock()
+	defer catchAllMu.RUnlock()
+	entry, ok := catchAllCache[domain]
+	if !ok || time.Now().After(entry.expires) {
+		return false, false
+	}
+	return entry.catchAll, true
+}
+
+// setCatchAllCache records the catch-all verdict for domain
+func setCatchAllCache(domain string, catchAll bool) {
+	catchAllMu.Lock()
+	catchAllCache[domain] = catchAllEntry{catchAll: catchAll, expires: time.Now().Add(catchAllCacheTTL)}
+	catchAllMu.Unlock()
+}
+
+// randomLocalPart generates a random n-character local part used to probe
+// whether a domain accepts mail for any address
+func randomLocalPart(n int) (string, error) {
+	b := make([]byte, n)
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(catchAllCharset))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = catchAllCharset[idx.Int64()]
+	}
+	return string(b), nil
+}
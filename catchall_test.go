@@ -0,0 +1,74 @@
+package verifier
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatchAllCacheRoundTrip(t *testing.T) {
+	domain := "catchall-test-round-trip.example"
+	defer deleteCatchAllCache(domain)
+
+	if _, ok := catchAllCached(domain); ok {
+		t.Fatal("expected no cached verdict before any set")
+	}
+
+	setCatchAllCache(domain, true)
+
+	catchAll, ok := catchAllCached(domain)
+	if !ok {
+		t.Fatal("expected a cached verdict after set")
+	}
+	if !catchAll {
+		t.Fatal("expected cached verdict to be true")
+	}
+}
+
+func TestCatchAllCacheExpiry(t *testing.T) {
+	domain := "catchall-test-expiry.example"
+	defer deleteCatchAllCache(domain)
+
+	catchAllMu.Lock()
+	catchAllCache[domain] = catchAllEntry{catchAll: true, expires: time.Now().Add(-time.Second)}
+	catchAllMu.Unlock()
+
+	if _, ok := catchAllCached(domain); ok {
+		t.Fatal("expected an expired entry to miss")
+	}
+}
+
+func TestRandomLocalPart(t *testing.T) {
+	localPart, err := randomLocalPart(catchAllLocalPartLength)
+	if err != nil {
+		t.Fatalf("randomLocalPart() failed: %v", err)
+	}
+	if len(localPart) != catchAllLocalPartLength {
+		t.Fatalf("randomLocalPart() length = %d, want %d", len(localPart), catchAllLocalPartLength)
+	}
+	for _, r := range localPart {
+		if !strings.ContainsRune(catchAllCharset, r) {
+			t.Fatalf("randomLocalPart() contains unexpected character %q", r)
+		}
+	}
+}
+
+func TestRandomLocalPartVaries(t *testing.T) {
+	a, err := randomLocalPart(catchAllLocalPartLength)
+	if err != nil {
+		t.Fatalf("randomLocalPart() failed: %v", err)
+	}
+	b, err := randomLocalPart(catchAllLocalPartLength)
+	if err != nil {
+		t.Fatalf("randomLocalPart() failed: %v", err)
+	}
+	if a == b {
+		t.Fatalf("randomLocalPart() returned the same value twice in a row: %q", a)
+	}
+}
+
+func deleteCatchAllCache(domain string) {
+	catchAllMu.Lock()
+	delete(catchAllCache, domain)
+	catchAllMu.Unlock()
+}
@@ -0,0 +1,54 @@
+package verifier
+
+import (
+	"net"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// dnsTTLSource resolves a record's authoritative TTL via a raw DNS query
+// against the first nameserver in /etc/resolv.conf, so cachingResolver can honor a
+// record's own DNS TTL instead of always falling back to its fixed ttl.
+// net.Resolver doesn't expose a looked-up record's TTL, so this bypasses it
+// for the sole purpose of reading that field.
+type dnsTTLSource struct {
+	client     *dns.Client
+	nameserver string
+}
+
+// newDNSTTLSource builds a dnsTTLSource from /etc/resolv.conf. It returns
+// nil when no nameserver can be determined (e.g. non-Unix hosts, containers
+// without resolv.conf), in which case callers fall back to a fixed TTL.
+func newDNSTTLSource() *dnsTTLSource {
+	conf, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+	if err != nil || len(conf.Servers) == 0 {
+		return nil
+	}
+	return &dnsTTLSource{
+		client:     &dns.Client{Timeout: 5 * time.Second},
+		nameserver: net.JoinHostPort(conf.Servers[0], conf.Port),
+	}
+}
+
+// ttl queries qtype for name and returns the lowest TTL among the answer
+// records, or ok=false if the source is unset, the query failed, or the
+// answer was empty (e.g. an NXDOMAIN, which carries no record TTL).
+func (s *dnsTTLSource) ttl(name string, qtype uint16) (time.Duration, bool) {
+	if s == nil {
+		return 0, false
+	}
+	msg := new(dns.Msg)
+	msg.SetQuestion(dns.Fqdn(name), qtype)
+	response, _, err := s.client.Exchange(msg, s.nameserver)
+	if err != nil || response == nil || len(response.Answer) == 0 {
+		return 0, false
+	}
+	minTTL := response.Answer[0].Header().Ttl
+	for _, rr := range response.Answer[1:] {
+		if rr.Header().Ttl < minTTL {
+			minTTL = rr.Header().Ttl
+		}
+	}
+	return time.Duration(minTTL) * time.Second, true
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/mail"
 	"net/smtp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -97,28 +98,41 @@ func Verify(input string, conf *Conf) (bool, error) {
 	if len(records) < 1 {
 		return false, ErrNoMXRecords
 	}
-	host := records[0].Host
-	pref := records[0].Pref
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Pref < records[j].Pref
+	})
+	var lastErr error
 	for _, record := range records {
-		if record.Pref >= pref {
-			continue
+		ok, rejected, err := probeSMTP(record.Host, input)
+		if err == nil {
+			return ok, nil
 		}
-		pref = record.Pref
-		host = record.Host
+		if rejected {
+			return false, err
+		}
+		lastErr = err
 	}
+	return false, lastErr
+}
+
+// probeSMTP dials host and attempts a MAIL FROM/RCPT TO transaction for input.
+// The second return value reports whether the failure is an explicit SMTP
+// rejection of the recipient, as opposed to a transient dial/connection error
+// that warrants falling back to the next MX host.
+func probeSMTP(host, input string) (bool, bool, error) {
 	addr := fmt.Sprintf("%[1]s:%[2]d", host, smtpPort)
 	client, err := smtp.Dial(addr)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 	defer client.Close()
 	err = client.Mail(fromEmail)
 	if err != nil {
-		return false, err
+		return false, false, err
 	}
 	err = client.Rcpt(input)
 	if err != nil {
-		return false, err
+		return false, true, err
 	}
-	return true, nil
+	return true, false, nil
 }
@@ -0,0 +1,101 @@
+package email
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+// startFakeSMTPServer listens on 127.0.0.1:25 (the fixed port probeSMTP
+// dials) and answers a single connection with a minimal EHLO/MAIL
+// handshake, responding to RCPT TO with rcptResponse. It skips the test
+// if the port can't be bound, e.g. no permission to bind a privileged
+// port in this environment.
+func startFakeSMTPServer(t *testing.T, rcptResponse string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:25")
+	if err != nil {
+		t.Skipf("cannot bind 127.0.0.1:25 in this environment: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 fake.test ESMTP ready")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch {
+			case hasCommand(line, "EHLO"), hasCommand(line, "HELO"):
+				tp.PrintfLine("250 fake.test")
+			case hasCommand(line, "MAIL FROM"):
+				tp.PrintfLine("250 OK")
+			case hasCommand(line, "RCPT TO"):
+				tp.PrintfLine(rcptResponse)
+			case hasCommand(line, "QUIT"):
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("500 unrecognized command")
+			}
+		}
+	}()
+}
+
+func hasCommand(line, command string) bool {
+	return strings.HasPrefix(strings.ToUpper(line), command)
+}
+
+func TestProbeSMTPAccepted(t *testing.T) {
+	startFakeSMTPServer(t, "250 OK")
+
+	ok, rejected, err := probeSMTP("127.0.0.1", "user@example.com")
+	if err != nil {
+		t.Fatalf("probeSMTP() returned error on accepted RCPT: %v", err)
+	}
+	if !ok {
+		t.Fatal("probeSMTP() ok = false, want true on accepted RCPT")
+	}
+	if rejected {
+		t.Fatal("probeSMTP() rejected = true, want false on accepted RCPT")
+	}
+}
+
+func TestProbeSMTPRejected(t *testing.T) {
+	startFakeSMTPServer(t, "550 no such user")
+
+	ok, rejected, err := probeSMTP("127.0.0.1", "user@example.com")
+	if err == nil {
+		t.Fatal("probeSMTP() expected an error on a rejected RCPT")
+	}
+	if !rejected {
+		t.Fatal("probeSMTP() rejected = false, want true on a rejected RCPT")
+	}
+	if ok {
+		t.Fatal("probeSMTP() ok = true, want false on a rejected RCPT")
+	}
+}
+
+func TestProbeSMTPDialFailure(t *testing.T) {
+	// No fake server listening: 127.0.0.1:25 should refuse the connection,
+	// which is the transient-failure case the MX fallback loop relies on
+	// to try the next host rather than giving up immediately.
+	ok, rejected, err := probeSMTP("127.0.0.1", "user@example.com")
+	if err == nil {
+		t.Fatal("probeSMTP() expected a dial error when nothing is listening")
+	}
+	if rejected {
+		t.Fatal("probeSMTP() rejected = true, want false for a dial failure")
+	}
+	if ok {
+		t.Fatal("probeSMTP() ok = true, want false for a dial failure")
+	}
+}
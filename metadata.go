@@ -0,0 +1,146 @@
+package verifier
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// commonFreeProviders lists well-known free/consumer email domains, also
+// used as the candidate set for typo suggestions
+var commonFreeProviders = []string{
+	"gmail.com", "yahoo.com", "outlook.com", "hotmail.com", "live.com",
+	"icloud.com", "aol.com", "protonmail.com", "proton.me", "gmx.com",
+	"mail.com", "zoho.com", "yandex.com",
+}
+
+// commonRoleAccounts lists local parts that typically address a role or
+// team rather than an individual
+var commonRoleAccounts = []string{
+	"info", "admin", "administrator", "support", "postmaster", "abuse",
+	"contact", "sales", "help", "webmaster", "noreply", "no-reply",
+	"billing", "marketing", "hr",
+}
+
+// Read-write mutex for thread-safe access to freeProviders
+var freeProvidersMu sync.RWMutex
+
+// Set of known free/consumer email provider domains
+var freeProviders map[string]struct{}
+
+// Read-write mutex for thread-safe access to roleAccounts
+var roleAccountsMu sync.RWMutex
+
+// Set of known role-account local parts
+var roleAccounts map[string]struct{}
+
+// init loads the free-provider and role-account lookup sets
+func init() {
+	refreshFreeProviders()
+	refreshRoleAccounts()
+}
+
+// refreshFreeProviders rebuilds the free-provider lookup set from the
+// embedded list. It runs on the same ticker loop as the disposable domains
+// list (which is fetched, not embedded) so all three lists share one
+// maintenance path.
+func refreshFreeProviders() {
+	providers := make(map[string]struct{}, len(commonFreeProviders))
+	for _, domain := range commonFreeProviders {
+		providers[domain] = struct{}{}
+	}
+	freeProvidersMu.Lock()
+	freeProviders = providers
+	freeProvidersMu.Unlock()
+}
+
+// refreshRoleAccounts rebuilds the role-account lookup set from the
+// embedded list
+func refreshRoleAccounts() {
+	accounts := make(map[string]struct{}, len(commonRoleAccounts))
+	for _, account := range commonRoleAccounts {
+		accounts[account] = struct{}{}
+	}
+	roleAccountsMu.Lock()
+	roleAccounts = accounts
+	roleAccountsMu.Unlock()
+}
+
+// checkGravatar reports whether address has a registered Gravatar image, via
+// a HEAD request against the avatar endpoint with 404-on-miss enabled
+func checkGravatar(ctx context.Context, address string) (bool, error) {
+	sum := md5.Sum([]byte(strings.ToLower(strings.TrimSpace(address))))
+	url := fmt.Sprintf("https://www.gravatar.com/avatar/%s?d=404", hex.EncodeToString(sum[:]))
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false, err
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	return response.StatusCode == http.StatusOK, nil
+}
+
+// suggestDomainCorrection returns the closest commonFreeProviders domain to
+// domain when it looks like a likely typo (Damerau-Levenshtein distance <= 2)
+// of one, or "" if domain is already an exact match or no close match exists.
+func suggestDomainCorrection(domain string) string {
+	best := ""
+	bestDistance := 3
+	for _, candidate := range commonFreeProviders {
+		if candidate == domain {
+			return ""
+		}
+		distance := damerauLevenshtein(domain, candidate)
+		if distance < bestDistance {
+			bestDistance = distance
+			best = candidate
+		}
+	}
+	return best
+}
+
+// damerauLevenshtein computes the Damerau-Levenshtein edit distance between
+// a and b: insertions, deletions, substitutions, and adjacent transpositions
+// each cost 1.
+func damerauLevenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				d[i][j] = min2(d[i][j], d[i-2][j-2]+cost)
+			}
+		}
+	}
+	return d[len(ar)][len(br)]
+}
+
+func min2(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func min3(a, b, c int) int {
+	return min2(min2(a, b), c)
+}
@@ -0,0 +1,50 @@
+package verifier
+
+import "testing"
+
+func TestDamerauLevenshtein(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{"identical", "gmail.com", "gmail.com", 0},
+		{"single substitution", "gmail.com", "gmail.con", 1},
+		{"single insertion", "gmailcom", "gmail.com", 1},
+		{"single deletion", "ggmail.com", "gmail.com", 1},
+		{"adjacent transposition", "gmali.com", "gmail.com", 1},
+		{"empty strings", "", "", 0},
+		{"one empty", "", "gmail.com", 9},
+		{"unrelated", "yahoo.com", "outlook.com", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := damerauLevenshtein(tt.a, tt.b); got != tt.want {
+				t.Fatalf("damerauLevenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuggestDomainCorrection(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		want   string
+	}{
+		{"exact match returns no suggestion", "gmail.com", ""},
+		{"close typo suggests provider", "gmial.com", "gmail.com"},
+		{"missing dot suggests provider", "gmailcom", "gmail.com"},
+		{"unrelated domain has no suggestion", "example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suggestDomainCorrection(tt.domain); got != tt.want {
+				t.Fatalf("suggestDomainCorrection(%q) = %q, want %q", tt.domain, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,9 @@
 package email
 
 import (
-	"fmt"
 	"net"
 	"net/mail"
-	"net/smtp"
+	"sort"
 	"strings"
 )
 
@@ -35,30 +34,21 @@ func (v *verifier) Verify(input string) (bool, error) {
 	if len(records) < 1 {
 		return false, ErrNoMXRecords
 	}
-	host := records[0].Host
-	pref := records[0].Pref
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Pref < records[j].Pref
+	})
+	var lastErr error
 	for _, record := range records {
-		if record.Pref >= pref {
-			continue
+		ok, rejected, err := probeSMTP(record.Host, input)
+		if err == nil {
+			return ok, nil
 		}
-		pref = record.Pref
-		host = record.Host
-	}
-	addr := fmt.Sprintf("%[1]s:%[2]d", host, smtpPort)
-	client, err := smtp.Dial(addr)
-	if err != nil {
-		return false, err
-	}
-	defer client.Close()
-	err = client.Mail(fromEmail)
-	if err != nil {
-		return false, err
-	}
-	err = client.Rcpt(input)
-	if err != nil {
-		return false, err
+		if rejected {
+			return false, err
+		}
+		lastErr = err
 	}
-	return true, nil
+	return false, lastErr
 }
 
 func (v *verifier) loop() {
@@ -0,0 +1,124 @@
+package verifier
+
+import (
+	"context"
+	"strings"
+)
+
+// SPFResult carries the sender domain's parsed SPF record
+type SPFResult struct {
+	Record       string // Raw "v=spf1 ..." TXT record, empty if none was found
+	AllQualifier string // Qualifier of the "all" mechanism: pass, fail, softfail, neutral, or empty if absent
+}
+
+// DMARCResult carries the sender domain's parsed DMARC record
+type DMARCResult struct {
+	Record          string // Raw "v=DMARC1; ..." TXT record, empty if none was found
+	Policy          string // p= tag: none, quarantine, or reject
+	SubdomainPolicy string // sp= tag, falls back to Policy when absent
+}
+
+// DKIMResult carries the lookup outcome for a single DKIM selector
+type DKIMResult struct {
+	Selector string // Selector this result is for
+	Record   string // Raw TXT record at selector._domainkey.<domain>
+	Found    bool   // Whether a DKIM record was published for the selector
+}
+
+// lookupSPF fetches and parses the SPF TXT record published on domain. A
+// domain with no other DNS records at its apex will NXDOMAIN on this lookup
+// just like any other name with no records; that's not an error, it just
+// means no SPF record was published.
+func lookupSPF(ctx context.Context, res Resolver, domain string) (*SPFResult, error) {
+	records, err := res.LookupTXT(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	result := &SPFResult{}
+	for _, record := range records {
+		if !strings.HasPrefix(record, "v=spf1") {
+			continue
+		}
+		result.Record = record
+		result.AllQualifier = spfAllQualifier(record)
+		break
+	}
+	return result, nil
+}
+
+// spfAllQualifier returns the qualifier of the "all" mechanism in an SPF
+// record (pass, fail, softfail, neutral), or "" if the record has none
+func spfAllQualifier(record string) string {
+	for _, term := range strings.Fields(record) {
+		switch term {
+		case "all", "+all":
+			return "pass"
+		case "-all":
+			return "fail"
+		case "~all":
+			return "softfail"
+		case "?all":
+			return "neutral"
+		}
+	}
+	return ""
+}
+
+// lookupDMARC fetches and parses the DMARC TXT record published at
+// _dmarc.<domain>. Most domains never publish one, so _dmarc.<domain>
+// NXDOMAINing is the common case, not an error.
+func lookupDMARC(ctx context.Context, res Resolver, domain string) (*DMARCResult, error) {
+	records, err := res.LookupTXT(ctx, "_dmarc."+domain)
+	if err != nil && !isNotFound(err) {
+		return nil, err
+	}
+	result := &DMARCResult{}
+	for _, record := range records {
+		if !strings.HasPrefix(record, "v=DMARC1") {
+			continue
+		}
+		result.Record = record
+		tags := dmarcTags(record)
+		result.Policy = tags["p"]
+		result.SubdomainPolicy = tags["sp"]
+		if result.SubdomainPolicy == "" {
+			result.SubdomainPolicy = result.Policy
+		}
+		break
+	}
+	return result, nil
+}
+
+// dmarcTags splits a DMARC record's "tag=value; tag=value" body into a map
+func dmarcTags(record string) map[string]string {
+	tags := map[string]string{}
+	for _, part := range strings.Split(record, ";") {
+		part = strings.TrimSpace(part)
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		tags[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return tags
+}
+
+// lookupDKIM fetches the DKIM TXT record for each selector at
+// <selector>._domainkey.<domain>
+func lookupDKIM(ctx context.Context, res Resolver, domain string, selectors []string) ([]DKIMResult, error) {
+	results := make([]DKIMResult, 0, len(selectors))
+	for _, selector := range selectors {
+		records, err := res.LookupTXT(ctx, selector+"._domainkey."+domain)
+		if err != nil {
+			results = append(results, DKIMResult{Selector: selector})
+			continue
+		}
+		result := DKIMResult{Selector: selector}
+		if len(records) > 0 {
+			result.Record = records[0]
+			result.Found = true
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,143 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+// stubResolver is a Resolver test double whose LookupTXT answer/error is
+// fixed per test, so lookupSPF/lookupDMARC can be exercised without a real
+// DNS round trip.
+type stubResolver struct {
+	txt    []string
+	txtErr error
+}
+
+func (s stubResolver) LookupNS(ctx context.Context, domain string) ([]*net.NS, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s stubResolver) LookupMX(ctx context.Context, domain string) ([]*net.MX, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s stubResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return s.txt, s.txtErr
+}
+
+func TestLookupSPFTreatsNXDOMAINAsNoRecord(t *testing.T) {
+	res := stubResolver{txtErr: &net.DNSError{Err: "no such host", IsNotFound: true}}
+
+	result, err := lookupSPF(context.Background(), res, "example.com")
+	if err != nil {
+		t.Fatalf("lookupSPF() returned error on NXDOMAIN: %v", err)
+	}
+	if result == nil || result.Record != "" || result.AllQualifier != "" {
+		t.Fatalf("lookupSPF() = %+v, want an empty SPFResult", result)
+	}
+}
+
+func TestLookupSPFPropagatesTransientError(t *testing.T) {
+	wantErr := &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+	res := stubResolver{txtErr: wantErr}
+
+	_, err := lookupSPF(context.Background(), res, "example.com")
+	if err != wantErr {
+		t.Fatalf("lookupSPF() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestLookupDMARCTreatsNXDOMAINAsNoRecord(t *testing.T) {
+	res := stubResolver{txtErr: &net.DNSError{Err: "no such host", IsNotFound: true}}
+
+	result, err := lookupDMARC(context.Background(), res, "example.com")
+	if err != nil {
+		t.Fatalf("lookupDMARC() returned error on NXDOMAIN: %v", err)
+	}
+	if result == nil || result.Record != "" || result.Policy != "" {
+		t.Fatalf("lookupDMARC() = %+v, want an empty DMARCResult", result)
+	}
+}
+
+func TestLookupDMARCPropagatesTransientError(t *testing.T) {
+	wantErr := &net.DNSError{Err: "server misbehaving", IsTemporary: true}
+	res := stubResolver{txtErr: wantErr}
+
+	_, err := lookupDMARC(context.Background(), res, "example.com")
+	if err != wantErr {
+		t.Fatalf("lookupDMARC() error = %v, want %v", err, wantErr)
+	}
+}
+
+func TestSPFAllQualifier(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		want   string
+	}{
+		{"implicit pass", "v=spf1 include:_spf.google.com all", "pass"},
+		{"explicit pass", "v=spf1 a mx +all", "pass"},
+		{"fail", "v=spf1 a mx -all", "fail"},
+		{"softfail", "v=spf1 a mx ~all", "softfail"},
+		{"neutral", "v=spf1 a mx ?all", "neutral"},
+		{"no all mechanism", "v=spf1 a mx", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spfAllQualifier(tt.record); got != tt.want {
+				t.Fatalf("spfAllQualifier(%q) = %q, want %q", tt.record, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDMARCTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		want   map[string]string
+	}{
+		{
+			name:   "policy and subdomain policy",
+			record: "v=DMARC1; p=reject; sp=quarantine; rua=mailto:dmarc@example.com",
+			want: map[string]string{
+				"v":   "DMARC1",
+				"p":   "reject",
+				"sp":  "quarantine",
+				"rua": "mailto:dmarc@example.com",
+			},
+		},
+		{
+			name:   "policy only",
+			record: "v=DMARC1; p=none",
+			want:   map[string]string{"v": "DMARC1", "p": "none"},
+		},
+		{
+			name:   "extra whitespace",
+			record: "v=DMARC1;  p = reject ;sp=none",
+			want:   map[string]string{"v": "DMARC1", "p": "reject", "sp": "none"},
+		},
+		{
+			name:   "malformed tag is skipped",
+			record: "v=DMARC1; p=reject; malformed; sp=none",
+			want:   map[string]string{"v": "DMARC1", "p": "reject", "sp": "none"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := dmarcTags(tt.record)
+			if len(got) != len(tt.want) {
+				t.Fatalf("dmarcTags(%q) = %v, want %v", tt.record, got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Fatalf("dmarcTags(%q)[%q] = %q, want %q", tt.record, k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gmailAPIVerifier checks Gmail/Google Workspace mailboxes via Google's
+// sign-in availability lookup instead of a raw SMTP RCPT TO, which Google
+// increasingly rejects from unrecognized IPs.
+type gmailAPIVerifier struct{}
+
+func (gmailAPIVerifier) IsSupported(mxHost string) bool {
+	return strings.HasSuffix(mxHost, ".l.google.com")
+}
+
+// Check is best-effort: Google may change or rate-limit the lookup endpoint
+// without notice, in which case the returned error lets Verify fall back to
+// the next MX host.
+func (gmailAPIVerifier) Check(ctx context.Context, domain, username string) (bool, error) {
+	return probeSignInLookup(ctx, "https://accounts.google.com/_/signin/sl/lookup", domain, username)
+}
+
+// yahooAPIVerifier checks Yahoo Mail mailboxes the same way, since Yahoo's
+// MTAs are similarly aggressive about throttling unknown-IP RCPT TO probes.
+type yahooAPIVerifier struct{}
+
+func (yahooAPIVerifier) IsSupported(mxHost string) bool {
+	return strings.HasSuffix(mxHost, ".yahoodns.net")
+}
+
+func (yahooAPIVerifier) Check(ctx context.Context, domain, username string) (bool, error) {
+	return probeSignInLookup(ctx, "https://login.yahoo.com/account/module/lookup", domain, username)
+}
+
+// probeSignInLookup is shared by the built-in adapters: it posts the
+// candidate address to a provider's sign-in/recovery lookup endpoint and
+// parses the JSON body for an existence signal. Both Google's and Yahoo's
+// lookup endpoints return HTTP 200 for almost any request regardless of
+// whether the address exists, so the status code alone proves nothing; the
+// body's errorCode field is what actually distinguishes a known account
+// from an unknown one.
+func probeSignInLookup(ctx context.Context, url, domain, username string) (bool, error) {
+	address := fmt.Sprintf("%[1]s@%[2]s", username, domain)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader("identifier="+address))
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return false, err
+	}
+	return parseSignInLookup(body)
+}
+
+// signInLookupResult is the minimal shape of a sign-in/recovery lookup
+// response relevant to existence checks.
+type signInLookupResult struct {
+	ErrorCode string `json:"errorCode"`
+}
+
+// signInLookupUnknownUser is the errorCode both providers report when the
+// submitted address doesn't resolve to any account. A match omits the field
+// (or reports it empty).
+const signInLookupUnknownUser = "USER_UNKNOWN"
+
+// parseSignInLookup interprets a sign-in lookup response body. Any errorCode
+// other than the known "no such user" value is treated as ambiguous (a
+// format change, a throttling page, a CAPTCHA challenge, ...) and returned
+// as an error so the caller falls back to the next MX host instead of
+// trusting a guess.
+func parseSignInLookup(body []byte) (bool, error) {
+	var result signInLookupResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return false, fmt.Errorf("parse sign-in lookup response: %w", err)
+	}
+	switch result.ErrorCode {
+	case "":
+		return true, nil
+	case signInLookupUnknownUser:
+		return false, nil
+	default:
+		return false, fmt.Errorf("ambiguous sign-in lookup errorCode %q", result.ErrorCode)
+	}
+}
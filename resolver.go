@@ -0,0 +1,216 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"golang.org/x/sync/singleflight"
+)
+
+// Default TTL applied to a cached lookup when its own DNS TTL can't be
+// determined, e.g. the environment has no reachable resolver to query
+// directly, or the answer's TTL couldn't be read.
+const defaultResolverTTL = 15 * time.Minute
+
+// Resolver abstracts the DNS lookups Verify depends on, so callers can plug
+// in a custom cache, a distributed backend (e.g. Redis-backed), or a test
+// double without touching Verify itself.
+type Resolver interface {
+	LookupNS(ctx context.Context, domain string) ([]*net.NS, error)
+	LookupMX(ctx context.Context, domain string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// ResolverMetrics receives counters for cache effectiveness. A nil field is
+// treated as a no-op.
+type ResolverMetrics struct {
+	Hit   func(domain string)
+	Miss  func(domain string)
+	Error func(domain, query string, err error)
+}
+
+type lookupEntry struct {
+	ns      []*net.NS
+	mx      []*net.MX
+	txt     []string
+	err     error
+	expires time.Time
+}
+
+// cachingResolver is the default Resolver: an in-memory cache over
+// net.Resolver that coalesces concurrent lookups for the same domain via
+// singleflight, plus a negative cache so a consistently-failing domain
+// (e.g. NXDOMAIN) isn't re-queried on every call. A successful answer is
+// cached for its own DNS TTL when ttlSource can determine one, falling back
+// to the fixed ttl otherwise; negative cache entries always use ttl, since
+// an NXDOMAIN response carries no record TTL of its own.
+type cachingResolver struct {
+	dns       *net.Resolver
+	ttl       time.Duration
+	ttlSource *dnsTTLSource
+	metrics   ResolverMetrics
+	group     singleflight.Group
+	mu        sync.RWMutex
+	ns        map[string]lookupEntry
+	mx        map[string]lookupEntry
+	txt       map[string]lookupEntry
+}
+
+// NewResolver returns the default Resolver implementation with the given
+// fallback TTL (0 uses defaultResolverTTL) and optional metrics hooks. When
+// the host has a reachable resolver configured in /etc/resolv.conf, cached
+// answers honor their own DNS TTL instead of the fallback.
+func NewResolver(ttl time.Duration, metrics ResolverMetrics) Resolver {
+	if ttl <= 0 {
+		ttl = defaultResolverTTL
+	}
+	return &cachingResolver{
+		dns:       &net.Resolver{},
+		ttl:       ttl,
+		ttlSource: newDNSTTLSource(),
+		metrics:   metrics,
+		ns:        map[string]lookupEntry{},
+		mx:        map[string]lookupEntry{},
+		txt:       map[string]lookupEntry{},
+	}
+}
+
+// entryTTL returns the DNS TTL answered for name's qtype record when
+// ttlSource can determine one, falling back to r.ttl otherwise.
+func (r *cachingResolver) entryTTL(name string, qtype uint16) time.Duration {
+	if ttl, ok := r.ttlSource.ttl(name, qtype); ok {
+		return ttl
+	}
+	return r.ttl
+}
+
+// defaultResolver backs package-level lookups when Config.Resolver is nil
+var defaultResolver = NewResolver(defaultResolverTTL, ResolverMetrics{})
+
+func (r *cachingResolver) LookupNS(ctx context.Context, domain string) ([]*net.NS, error) {
+	r.mu.RLock()
+	entry, ok := r.ns[domain]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(entry.expires) {
+		r.hit(domain)
+		return entry.ns, entry.err
+	}
+	r.miss(domain)
+	v, err, _ := r.group.Do("ns:"+domain, func() (interface{}, error) {
+		records, lookupErr := r.dns.LookupNS(ctx, domain)
+		switch {
+		case lookupErr == nil:
+			r.mu.Lock()
+			r.ns[domain] = lookupEntry{ns: records, expires: time.Now().Add(r.entryTTL(domain, dns.TypeNS))}
+			r.mu.Unlock()
+		case isNotFound(lookupErr):
+			r.mu.Lock()
+			r.ns[domain] = lookupEntry{err: lookupErr, expires: time.Now().Add(r.ttl)}
+			r.mu.Unlock()
+		}
+		if lookupErr != nil {
+			r.error(domain, "ns", lookupErr)
+		}
+		return records, lookupErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*net.NS), nil
+}
+
+func (r *cachingResolver) LookupMX(ctx context.Context, domain string) ([]*net.MX, error) {
+	r.mu.RLock()
+	entry, ok := r.mx[domain]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(entry.expires) {
+		r.hit(domain)
+		return entry.mx, entry.err
+	}
+	r.miss(domain)
+	v, err, _ := r.group.Do("mx:"+domain, func() (interface{}, error) {
+		records, lookupErr := r.dns.LookupMX(ctx, domain)
+		switch {
+		case lookupErr == nil:
+			r.mu.Lock()
+			r.mx[domain] = lookupEntry{mx: records, expires: time.Now().Add(r.entryTTL(domain, dns.TypeMX))}
+			r.mu.Unlock()
+		case isNotFound(lookupErr):
+			r.mu.Lock()
+			r.mx[domain] = lookupEntry{err: lookupErr, expires: time.Now().Add(r.ttl)}
+			r.mu.Unlock()
+		}
+		if lookupErr != nil {
+			r.error(domain, "mx", lookupErr)
+		}
+		return records, lookupErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]*net.MX), nil
+}
+
+func (r *cachingResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	r.mu.RLock()
+	entry, ok := r.txt[name]
+	r.mu.RUnlock()
+	if ok && time.Now().Before(entry.expires) {
+		r.hit(name)
+		return entry.txt, entry.err
+	}
+	r.miss(name)
+	v, err, _ := r.group.Do("txt:"+name, func() (interface{}, error) {
+		records, lookupErr := r.dns.LookupTXT(ctx, name)
+		switch {
+		case lookupErr == nil:
+			r.mu.Lock()
+			r.txt[name] = lookupEntry{txt: records, expires: time.Now().Add(r.entryTTL(name, dns.TypeTXT))}
+			r.mu.Unlock()
+		case isNotFound(lookupErr):
+			r.mu.Lock()
+			r.txt[name] = lookupEntry{err: lookupErr, expires: time.Now().Add(r.ttl)}
+			r.mu.Unlock()
+		}
+		if lookupErr != nil {
+			r.error(name, "txt", lookupErr)
+		}
+		return records, lookupErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.([]string), nil
+}
+
+func (r *cachingResolver) hit(domain string) {
+	if r.metrics.Hit != nil {
+		r.metrics.Hit(domain)
+	}
+}
+
+func (r *cachingResolver) miss(domain string) {
+	if r.metrics.Miss != nil {
+		r.metrics.Miss(domain)
+	}
+}
+
+func (r *cachingResolver) error(domain, query string, err error) {
+	if r.metrics.Error != nil {
+		r.metrics.Error(domain, query, err)
+	}
+}
+
+// isNotFound reports whether err represents a genuine "no such record" DNS
+// answer (e.g. NXDOMAIN), as opposed to a transient failure such as a
+// timeout or an unreachable resolver. Only the former is safe to hold in the
+// negative cache for the full TTL; caching the latter would keep returning a
+// stale failure for the rest of a transient outage.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
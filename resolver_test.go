@@ -0,0 +1,125 @@
+package verifier
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nxdomain", &net.DNSError{Err: "no such host", IsNotFound: true}, true},
+		{"timeout", &net.DNSError{Err: "i/o timeout", IsTimeout: true}, false},
+		{"temporary", &net.DNSError{Err: "server misbehaving", IsTemporary: true}, false},
+		{"non-dns error", context.DeadlineExceeded, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNotFound(tt.err); got != tt.want {
+				t.Fatalf("isNotFound(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachingResolverCachesSuccessfulLookup(t *testing.T) {
+	var hits, misses int
+	res, ok := NewResolver(time.Minute, ResolverMetrics{
+		Hit:  func(domain string) { hits++ },
+		Miss: func(domain string) { misses++ },
+	}).(*cachingResolver)
+	if !ok {
+		t.Fatal("NewResolver did not return a *cachingResolver")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := res.LookupMX(ctx, "gmail.com"); err != nil {
+		t.Fatalf("first LookupMX failed: %v", err)
+	}
+	if _, err := res.LookupMX(ctx, "gmail.com"); err != nil {
+		t.Fatalf("second LookupMX failed: %v", err)
+	}
+	if misses != 1 {
+		t.Fatalf("expected 1 miss, got %d", misses)
+	}
+	if hits != 1 {
+		t.Fatalf("expected 1 hit, got %d", hits)
+	}
+}
+
+func TestCachingResolverNegativeCachesNotFound(t *testing.T) {
+	res, ok := NewResolver(time.Minute, ResolverMetrics{}).(*cachingResolver)
+	if !ok {
+		t.Fatal("NewResolver did not return a *cachingResolver")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	domain := "nonexistentdomain12345.invalid"
+	if _, err := res.LookupMX(ctx, domain); err == nil {
+		t.Fatal("expected lookup error for nonexistent domain")
+	}
+
+	res.mu.RLock()
+	entry, cached := res.mx[domain]
+	res.mu.RUnlock()
+	if !cached {
+		t.Fatal("expected a not-found result to be cached")
+	}
+	if !isNotFound(entry.err) {
+		t.Fatalf("expected cached error to be a not-found DNSError, got %v", entry.err)
+	}
+}
+
+func TestCachingResolverEntryTTLFallsBackWithoutSource(t *testing.T) {
+	res, ok := NewResolver(42*time.Second, ResolverMetrics{}).(*cachingResolver)
+	if !ok {
+		t.Fatal("NewResolver did not return a *cachingResolver")
+	}
+	res.ttlSource = nil
+
+	if got := res.entryTTL("gmail.com", dns.TypeMX); got != 42*time.Second {
+		t.Fatalf("entryTTL() = %v, want the fixed fallback of 42s", got)
+	}
+}
+
+func TestCachingResolverSingleflightCoalesces(t *testing.T) {
+	var misses int
+	res, ok := NewResolver(time.Minute, ResolverMetrics{
+		Miss: func(domain string) { misses++ },
+	}).(*cachingResolver)
+	if !ok {
+		t.Fatal("NewResolver did not return a *cachingResolver")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	done := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := res.LookupMX(ctx, "gmail.com")
+			done <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Fatalf("concurrent LookupMX failed: %v", err)
+		}
+	}
+	if misses != 2 {
+		t.Fatalf("expected both concurrent calls to count as misses against the cache, got %d", misses)
+	}
+}
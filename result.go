@@ -0,0 +1,21 @@
+package verifier
+
+// Result carries the outcome of each subcheck VerifyDetailed ran, beyond the
+// single pass/fail boolean Verify exposes.
+type Result struct {
+	Valid               bool         // Whether the address passed every enabled check
+	IsValidSyntax       bool         // Whether the address parsed as a valid RFC address
+	IsFreeProvider      bool         // Whether the domain belongs to a known free/consumer email provider
+	IsRoleAccount       bool         // Whether the local part looks like a role/team inbox (info@, admin@, ...)
+	IsDisposable        bool         // Whether the domain is a known disposable/temporary email provider
+	IsCatchAll          bool         // Whether the destination MX accepts mail for any local part
+	HasMX               bool         // Whether the domain has MX records, populated when MX or SMTP validation is enabled
+	HasGravatar         bool         // Whether the address has a registered Gravatar image, populated when Config.CheckGravatar is set
+	SuggestedCorrection string       // Likely intended domain if the supplied one looks like a typo of a common provider, else ""
+	SPF                 *SPFResult   // Populated when Config.ValidateSPF is set
+	DMARC               *DMARCResult // Populated when Config.ValidateDMARC is set
+	DKIM                []DKIMResult // One entry per Config.DKIMSelectors, when Config.ValidateDKIM is set
+	DNSError            error        // Result of the NS lookup, populated when Config.ValidateDNS is set
+	MXError             error        // Result of the MX lookup, populated when Config.ValidateMX or Config.ValidateSMTP is set
+	SMTPError           error        // Result of the SMTP probe, populated when Config.ValidateSMTP is set
+}
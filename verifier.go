@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/mail"
 	"net/smtp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -26,10 +27,19 @@ const (
 
 // Config defines the validation options for email verification
 type Config struct {
-	ValidateMX      bool // Check if domain has MX (Mail Exchange) records
-	ValidateSMTP    bool // Test actual SMTP connection to verify email deliverability
-	ValidateDNS     bool // Verify domain has valid DNS records
-	BlockDisposable bool // Reject emails from known disposable/temporary email providers
+	ValidateMX      bool          // Check if domain has MX (Mail Exchange) records
+	ValidateSMTP    bool          // Test actual SMTP connection to verify email deliverability
+	ValidateDNS     bool          // Verify domain has valid DNS records
+	BlockDisposable bool          // Reject emails from known disposable/temporary email providers
+	APIVerifiers    []APIVerifier // Provider adapters consulted before the global registry, for this call only
+	DetectCatchAll  bool          // Probe whether the destination MX accepts mail for any address
+	Resolver        Resolver      // DNS resolver to use; defaults to an in-memory TTL'd cache when nil
+	ValidateSPF     bool          // Look up and parse the domain's SPF TXT record
+	ValidateDMARC   bool          // Look up and parse the domain's DMARC TXT record
+	ValidateDKIM    bool          // Look up a DKIM TXT record for each of DKIMSelectors
+	DKIMSelectors   []string      // Selectors to check when ValidateDKIM is set
+	CheckGravatar   bool          // Look up whether the address has a registered Gravatar image
+	PerCheckTimeout time.Duration // Timeout applied to each independent subcheck; 0 uses the context deadline
 }
 
 // Read-write mutex for thread-safe access to disposableDomains
@@ -41,9 +51,6 @@ var disposableDomains map[string]struct{}
 // Ticker for periodic updates of disposable domains list
 var tk *time.Ticker
 
-// DNS resolver for domain lookups
-var resolver = &net.Resolver{}
-
 // Network dialer for SMTP connections
 var dialer = &net.Dialer{}
 
@@ -57,6 +64,9 @@ var (
 	ErrDisposableEmail = errors.New("disposable domain")
 	// Domain has no MX records
 	ErrNoMXRecords = errors.New("mx record not found")
+	// Destination MX accepts mail for any local part, so a successful RCPT
+	// is not proof the address itself exists
+	ErrCatchAll = errors.New("catch-all domain")
 )
 
 // init initializes the package by setting up the disposable domains list and starting
@@ -114,7 +124,9 @@ func loop() {
 	}
 }
 
-// refresh updates the global disposable domains list with the latest data
+// refresh updates the global disposable domains list with the latest data,
+// along with the embedded free-provider and role-account lists so all three
+// share the same maintenance path
 func refresh() error {
 	// Fetch new domains list
 	domains, err := getDisposableDomains()
@@ -125,100 +137,270 @@ func refresh() error {
 	mu.Lock()
 	disposableDomains = domains
 	mu.Unlock()
+	refreshFreeProviders()
+	refreshRoleAccounts()
 	return nil
 }
 
 // Verify performs comprehensive email address validation based on the provided configuration
 // Returns true if email is valid according to the specified checks, false otherwise
 func Verify(ctx context.Context, input string, conf *Config) (bool, error) {
+	result, err := VerifyDetailed(ctx, input, conf)
+	if err != nil {
+		return false, err
+	}
+	if result.IsCatchAll {
+		return result.Valid, ErrCatchAll
+	}
+	return result.Valid, nil
+}
+
+// VerifyDetailed performs the same checks as Verify but returns a Result
+// describing the outcome of each enabled subcheck rather than a single
+// boolean.
+func VerifyDetailed(ctx context.Context, input string, conf *Config) (*Result, error) {
 	// Parse email address to ensure basic RFC compliance
 	email, err := mail.ParseAddress(input)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	// Extract domain from email address
 	i := strings.LastIndex(email.Address, "@")
 	if i < 0 || i == len(email.Address)-1 {
-		return false, ErrInvalidSyntax
+		return nil, ErrInvalidSyntax
 	}
 	domain := email.Address[i+1:]
-	// Check if domain is in disposable domains list (if enabled)
-	if conf.BlockDisposable {
+	// Use the caller-supplied resolver, if any, falling back to the shared
+	// TTL'd cache
+	res := conf.Resolver
+	if res == nil {
+		res = defaultResolver
+	}
+	username := email.Address[:i]
+	result := &Result{IsValidSyntax: true}
+
+	// Run the independent lookups concurrently: the disposable-domain check
+	// is a cheap map read, but NS and MX are real network calls, and a slow
+	// NS lookup shouldn't serialize the whole verification. SMTP depends on
+	// the MX result so it starts only after this fan-in completes.
+	var (
+		disposable bool
+		dnsErr     error
+		mxRecords  []*net.MX
+		mxErr      error
+	)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
 		mu.RLock()
-		_, ok := disposableDomains[domain]
+		_, disposable = disposableDomains[domain]
 		mu.RUnlock()
-		if ok {
-			return false, ErrDisposableEmail
-		}
+	}()
+	if conf.ValidateDNS {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := perCheckContext(ctx, conf.PerCheckTimeout)
+			defer cancel()
+			_, dnsErr = res.LookupNS(checkCtx, domain)
+		}()
 	}
-	// Validate DNS records for the domain (if enabled)
+	if conf.ValidateMX || conf.ValidateSMTP {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := perCheckContext(ctx, conf.PerCheckTimeout)
+			defer cancel()
+			mxRecords, mxErr = res.LookupMX(checkCtx, domain)
+		}()
+	}
+	wg.Wait()
+
+	// Populate every field the fan-in above already computed before deciding
+	// which error, if any, to return: a caller inspecting Result after an
+	// early return should see every subcheck that actually ran, not just the
+	// one that happened to trigger the return.
+	result.IsDisposable = disposable
 	if conf.ValidateDNS {
-		_, err = resolver.LookupNS(ctx, domain)
+		result.DNSError = dnsErr
+	}
+	if conf.ValidateMX || conf.ValidateSMTP {
+		result.MXError = mxErr
+		result.HasMX = len(mxRecords) > 0
+	}
+
+	if conf.BlockDisposable && result.IsDisposable {
+		return result, ErrDisposableEmail
+	}
+	// Flag free providers and role accounts; cheap local lookups, always populated
+	freeProvidersMu.RLock()
+	_, result.IsFreeProvider = freeProviders[domain]
+	freeProvidersMu.RUnlock()
+	roleAccountsMu.RLock()
+	_, result.IsRoleAccount = roleAccounts[strings.ToLower(username)]
+	roleAccountsMu.RUnlock()
+	result.SuggestedCorrection = suggestDomainCorrection(domain)
+	// Look up whether the address has a registered Gravatar image (if enabled)
+	if conf.CheckGravatar {
+		result.HasGravatar, err = checkGravatar(ctx, email.Address)
+		if err != nil {
+			return result, err
+		}
+	}
+	if conf.ValidateDNS && dnsErr != nil {
+		return result, dnsErr
+	}
+	// Look up sender domain policy records (if enabled)
+	if conf.ValidateSPF {
+		result.SPF, err = lookupSPF(ctx, res, domain)
 		if err != nil {
-			return false, err
+			return result, err
+		}
+	}
+	if conf.ValidateDMARC {
+		result.DMARC, err = lookupDMARC(ctx, res, domain)
+		if err != nil {
+			return result, err
+		}
+	}
+	if conf.ValidateDKIM {
+		result.DKIM, err = lookupDKIM(ctx, res, domain, conf.DKIMSelectors)
+		if err != nil {
+			return result, err
 		}
 	}
 	// Validate MX records and optionally test SMTP connection
 	if conf.ValidateMX || conf.ValidateSMTP {
-		records, err := resolver.LookupMX(ctx, domain)
-		if err != nil {
-			return false, err
+		if mxErr != nil {
+			return result, mxErr
 		}
+		records := mxRecords
 		if len(records) < 1 {
-			return false, ErrNoMXRecords
+			return result, ErrNoMXRecords
 		}
 		// If SMTP validation is enabled, test actual mail server connection
 		if conf.ValidateSMTP {
-			// Find MX record with lowest preference (highest priority)
-			host := records[0].Host
-			pref := records[0].Pref
+			// Sort MX records by preference ascending so the primary host is tried first
+			sort.Slice(records, func(i, j int) bool {
+				return records[i].Pref < records[j].Pref
+			})
+			// Walk the MX list, falling back to the next host on transient dial/connection
+			// failures, but stopping immediately on an explicit RCPT rejection
+			var lastErr error
 			for _, record := range records {
-				if record.Pref >= pref {
+				// Providers like Gmail and Yahoo throttle or reject RCPT TO probes
+				// from unknown IPs; prefer a registered API adapter when one
+				// matches this MX host
+				if v := apiVerifierFor(record.Host, conf.APIVerifiers); v != nil {
+					ok, err := v.Check(ctx, domain, username)
+					if err == nil {
+						result.Valid = ok
+						return result, nil
+					}
+					lastErr = err
 					continue
 				}
-				pref = record.Pref
-				host = record.Host
-			}
-			// Establish TCP connection to mail server
-			addr := fmt.Sprintf("%[1]s:%[2]d", host, smtpPort)
-			conn, err := dialer.DialContext(ctx, "tcp", addr)
-			if err != nil {
-				return false, err
-			}
-			defer conn.Close()
-			// Set connection deadline based on context or default timeout
-			deadline, ok := ctx.Deadline()
-			if !ok {
-				deadline = time.Now().Add(timeout)
-			}
-			err = conn.SetDeadline(deadline)
-			if err != nil {
-				return false, err
-			}
-			// Create SMTP client and perform mail transaction test
-			client, err := smtp.NewClient(conn, host)
-			if err != nil {
-				return false, err
-			}
-			defer func() {
-				// Clean up SMTP connection
-				err := client.Quit()
-				if err != nil {
-					client.Close()
+				ok, rejected, catchAll, err := probeSMTP(ctx, record.Host, input, domain, conf.DetectCatchAll)
+				if err == nil {
+					result.Valid = ok
+					result.IsCatchAll = catchAll
+					return result, nil
 				}
-			}()
-			// Test MAIL FROM command
-			err = client.Mail(fromEmail)
-			if err != nil {
-				return false, err
-			}
-			// Test RCPT TO command with the target email address
-			err = client.Rcpt(input)
-			if err != nil {
-				return false, err
+				if rejected {
+					result.SMTPError = err
+					return result, err
+				}
+				lastErr = err
 			}
+			result.SMTPError = lastErr
+			return result, lastErr
 		}
 	}
 	// All validations passed
-	return true, nil
+	result.Valid = true
+	return result, nil
+}
+
+// perCheckContext derives a context bounded by timeout, or ctx unchanged
+// (with a no-op cancel) when timeout is unset.
+func perCheckContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// probeSMTP dials host and attempts a MAIL FROM/RCPT TO transaction for input.
+// The second return value reports whether the failure is an explicit SMTP
+// rejection of the recipient, as opposed to a transient dial/connection error
+// that warrants falling back to the next MX host. When detectCatchAll is
+// set and the RCPT for input succeeds, a second RCPT for a random local part
+// on domain is issued on the same session to check whether the MX accepts
+// mail for any address; the verdict is cached per domain.
+func probeSMTP(ctx context.Context, host, input, domain string, detectCatchAll bool) (bool, bool, bool, error) {
+	// Establish TCP connection to mail server
+	addr := fmt.Sprintf("%[1]s:%[2]d", host, smtpPort)
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return false, false, false, err
+	}
+	defer conn.Close()
+	// Set connection deadline based on context or default timeout
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(timeout)
+	}
+	err = conn.SetDeadline(deadline)
+	if err != nil {
+		return false, false, false, err
+	}
+	// Create SMTP client and perform mail transaction test
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return false, false, false, err
+	}
+	defer func() {
+		// Clean up SMTP connection
+		err := client.Quit()
+		if err != nil {
+			client.Close()
+		}
+	}()
+	// Test MAIL FROM command
+	err = client.Mail(fromEmail)
+	if err != nil {
+		return false, false, false, err
+	}
+	// Test RCPT TO command with the target email address
+	err = client.Rcpt(input)
+	if err != nil {
+		return false, true, false, err
+	}
+	if !detectCatchAll {
+		return true, false, false, nil
+	}
+	catchAll, err := probeCatchAll(client, domain)
+	if err != nil {
+		return true, false, false, err
+	}
+	return true, false, catchAll, nil
+}
+
+// probeCatchAll checks, on an SMTP session that has already accepted MAIL FROM
+// and the RCPT for the real address, whether
+// domain accepts mail for a random non-existent local part. Verdicts are
+// cached per domain to avoid hammering the MX on every call.
+func probeCatchAll(client *smtp.Client, domain string) (bool, error) {
+	if catchAll, ok := catchAllCached(domain); ok {
+		return catchAll, nil
+	}
+	localPart, err := randomLocalPart(catchAllLocalPartLength)
+	if err != nil {
+		return false, err
+	}
+	probe := fmt.Sprintf("%[1]s@%[2]s", localPart, domain)
+	catchAll := client.Rcpt(probe) == nil
+	setCatchAllCache(domain, catchAll)
+	return catchAll, nil
 }
@@ -494,3 +494,33 @@ func TestErrorTypes(t *testing.T) {
 		}
 	})
 }
+
+// Test that VerifyDetailed populates every field its concurrent fan-in
+// already computed, even when an earlier check's error triggers the return.
+func TestVerifyDetailedPopulatesConcurrentFieldsBeforeEarlyReturn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	conf := &Config{
+		ValidateDNS: true,
+		ValidateMX:  true,
+	}
+
+	result, err := VerifyDetailed(ctx, "test@nonexistentdomain12345.com", conf)
+	if err == nil {
+		t.Fatal("expected a DNS error for a nonexistent domain")
+	}
+	if result == nil {
+		t.Fatal("expected a non-nil Result even though VerifyDetailed returned an error")
+	}
+	if result.DNSError == nil {
+		t.Fatal("expected Result.DNSError to be populated")
+	}
+	// The MX lookup runs concurrently with the NS lookup and has already
+	// completed by the time the NS error short-circuits the function; its
+	// result must still land on Result even though the DNS check returns
+	// first.
+	if result.MXError == nil {
+		t.Fatal("expected Result.MXError to be populated even though DNSError triggered the early return")
+	}
+}